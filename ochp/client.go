@@ -0,0 +1,55 @@
+package ochp
+
+import "context"
+
+/* This file introduces the client-side integration point that the generated
+ * procedure stubs call through: an Invoker chained with zero or more
+ * Interceptors. The stubs themselves, and the HTTP transport they sit on top
+ * of, are generated per WSDL and are not part of this package.
+ */
+
+// Invoker performs a single OCHP procedure call. operation is the procedure
+// name (e.g. "SetChargePointList"), req is the request struct generated for
+// that procedure, and the returned error, if any, is an OchpErr.
+type Invoker func(ctx context.Context, operation string, req any) (any, OchpErr)
+
+// Interceptor wraps an Invoker to observe or alter the call it makes, the
+// response it gets back, or the OchpErr it returns. Interceptors compose
+// like gRPC/Connect interceptors or go-kit ServerOptions: the first
+// Interceptor passed to Chain is the outermost layer.
+type Interceptor func(next Invoker) Invoker
+
+// Chain composes interceptors into a single Interceptor, in the order given:
+// Chain(a, b, c)(invoker) calls a, then b, then c, then invoker.
+func Chain(interceptors ...Interceptor) Interceptor {
+	return func(next Invoker) Invoker {
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			next = interceptors[i](next)
+		}
+		return next
+	}
+}
+
+// Client invokes OCHP procedures through a chain of Interceptors around an
+// underlying Invoker, which generated stubs call through instead of
+// performing the HTTP round trip themselves.
+type Client struct {
+	invoke Invoker
+}
+
+// NewClient builds a Client that calls invoke through interceptors, applied
+// in the order given to Chain.
+func NewClient(invoke Invoker, interceptors ...Interceptor) *Client {
+	return &Client{invoke: Chain(interceptors...)(invoke)}
+}
+
+// Invoke calls operation with req through the Client's interceptor chain. Any
+// returned OchpErr is tagged with operation, retrievable via its Operation()
+// method and included under "operation" in its JSON representation.
+func (c *Client) Invoke(ctx context.Context, operation string, req any) (any, OchpErr) {
+	resp, err := c.invoke(ctx, operation, req)
+	if err != nil {
+		err = withOperation{OchpErr: err, operation: operation}
+	}
+	return resp, err
+}
@@ -0,0 +1,102 @@
+package ochp
+
+import "errors"
+
+// Code is a coarse-grained classification of an OchpErr, analogous to a gRPC
+// or Twirp status code. It lets callers switch on failure category without
+// giving up the more detailed typed errors (ErrNotFound, ErrPartly, etc.).
+type Code string
+
+const (
+	// CodeOK is returned by errors whose underlying resultCode was "ok". In
+	// practice this should not occur, since a successful call returns no error.
+	CodeOK Code = "ok"
+	// CodeNotFound corresponds to resultCode "not-found".
+	CodeNotFound Code = "not-found"
+	// CodeNotAuthorized corresponds to resultCode "not-authorized".
+	CodeNotAuthorized Code = "not-authorized"
+	// CodeNotSupported corresponds to resultCode "not-supported".
+	CodeNotSupported Code = "not-supported"
+	// CodeInvalidId corresponds to resultCode "invalid-id".
+	CodeInvalidId Code = "invalid-id"
+	// CodeServer corresponds to resultCode "server".
+	CodeServer Code = "server"
+	// CodeFormat corresponds to resultCode "format".
+	CodeFormat Code = "format"
+	// CodeRoaming corresponds to resultCode "roaming".
+	CodeRoaming Code = "roaming"
+	// CodePartly corresponds to resultCode "partly".
+	CodePartly Code = "partly"
+	// CodeUnknown is used when no resultCode is available (e.g. Err, ErrDecode)
+	// or when the resultCode did not match any known value.
+	CodeUnknown Code = "unknown"
+)
+
+// codeForResultCode maps the raw resultCode string found in an OCHP Result
+// object to its corresponding Code.
+var codeForResultCode = map[string]Code{
+	"ok":             CodeOK,
+	"not-found":      CodeNotFound,
+	"not-authorized": CodeNotAuthorized,
+	"not-supported":  CodeNotSupported,
+	"invalid-id":     CodeInvalidId,
+	"server":         CodeServer,
+	"format":         CodeFormat,
+	"roaming":        CodeRoaming,
+	"partly":         CodePartly,
+}
+
+// codeError is a sentinel error that only carries a Code. It is never
+// returned by this package directly; it exists so that callers can write
+// errors.Is(err, ochp.ErrCodeNotFound) against any OchpErr that classifies as
+// that code, regardless of its concrete type.
+type codeError struct{ code Code }
+
+func (e *codeError) Error() string {
+	return "ochp: code " + string(e.code)
+}
+
+// Sentinel errors for use with errors.Is. They match any OchpErr whose Code()
+// equals the corresponding constant, e.g.:
+//
+//	if errors.Is(err, ochp.ErrCodeNotFound) { ... }
+var (
+	ErrCodeOK            = &codeError{CodeOK}
+	ErrCodeNotFound      = &codeError{CodeNotFound}
+	ErrCodeNotAuthorized = &codeError{CodeNotAuthorized}
+	ErrCodeNotSupported  = &codeError{CodeNotSupported}
+	ErrCodeInvalidId     = &codeError{CodeInvalidId}
+	ErrCodeServer        = &codeError{CodeServer}
+	ErrCodeFormat        = &codeError{CodeFormat}
+	ErrCodeRoaming       = &codeError{CodeRoaming}
+	ErrCodePartly        = &codeError{CodePartly}
+	ErrCodeUnknown       = &codeError{CodeUnknown}
+)
+
+// Code returns e as the Code it represents, for direct comparisons that don't
+// need errors.Is.
+func (e *codeError) Code() Code { return e.code }
+
+// is reports whether target is a sentinel Code error matching code. It is
+// shared by Err and ochpErr so that every concrete error type in this
+// package supports errors.Is(err, ochp.ErrCodeXxx) through promotion.
+func is(code Code, target error) bool {
+	var ce *codeError
+	if errors.As(target, &ce) {
+		return code == ce.code
+	}
+	return false
+}
+
+// CodeOf walks the wrap chain of err looking for an OchpErr and returns its
+// Code. It returns CodeUnknown if err is nil or does not wrap an OchpErr.
+func CodeOf(err error) Code {
+	if err == nil {
+		return CodeUnknown
+	}
+	var oe OchpErr
+	if errors.As(err, &oe) {
+		return oe.Code()
+	}
+	return CodeUnknown
+}
@@ -0,0 +1,72 @@
+package ochp
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestErrorsIsMatchesByCodeAcrossConcreteTypes(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		matches error
+		other   error
+	}{
+		{
+			name:    "ErrNotFound matches ErrCodeNotFound",
+			err:     ErrNotFound{ochpErr{resultCode: string(CodeNotFound)}},
+			matches: ErrCodeNotFound,
+			other:   ErrCodeServer,
+		},
+		{
+			name:    "ErrPartly matches ErrCodePartly",
+			err:     ErrPartly{ochpErr{resultCode: string(CodePartly)}},
+			matches: ErrCodePartly,
+			other:   ErrCodeFormat,
+		},
+		{
+			name:    "ErrUnknownResultCode matches ErrCodeUnknown",
+			err:     ErrUnknownResultCode{ochpErr{resultCode: "something-new"}},
+			matches: ErrCodeUnknown,
+			other:   ErrCodeNotFound,
+		},
+		{
+			name:    "Err (no resultCode) matches ErrCodeUnknown",
+			err:     Err{},
+			matches: ErrCodeUnknown,
+			other:   ErrCodeServer,
+		},
+		{
+			name: "wrapped ErrServer still matches ErrCodeServer",
+			err: fmt.Errorf("calling SetChargePointList: %w",
+				ErrServer{ochpErr{resultCode: string(CodeServer)}}),
+			matches: ErrCodeServer,
+			other:   ErrCodeRoaming,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !errors.Is(tt.err, tt.matches) {
+				t.Errorf("errors.Is(%v, %v) = false, want true", tt.err, tt.matches)
+			}
+			if errors.Is(tt.err, tt.other) {
+				t.Errorf("errors.Is(%v, %v) = true, want false", tt.err, tt.other)
+			}
+		})
+	}
+}
+
+func TestCodeOfWalksWrapChain(t *testing.T) {
+	err := fmt.Errorf("outer: %w", fmt.Errorf("inner: %w", ErrRoaming{ochpErr{resultCode: string(CodeRoaming)}}))
+	if got := CodeOf(err); got != CodeRoaming {
+		t.Errorf("CodeOf(err) = %q, want %q", got, CodeRoaming)
+	}
+	if got := CodeOf(nil); got != CodeUnknown {
+		t.Errorf("CodeOf(nil) = %q, want %q", got, CodeUnknown)
+	}
+	if got := CodeOf(errors.New("plain error")); got != CodeUnknown {
+		t.Errorf("CodeOf(plain error) = %q, want %q", got, CodeUnknown)
+	}
+}
@@ -0,0 +1,70 @@
+package ochp
+
+import "net/http"
+
+// FailedRecord describes a single item that an OCHP batch procedure (e.g.
+// SetChargePointList, SetRoamingAuthorisationList, SetCDRs) was unable to
+// process. It is attached as a detail on ErrPartly so that callers can retry
+// only the failing subset of a batch instead of the whole request.
+type FailedRecord struct {
+	// ID is the identifier of the failed item as sent in the request, e.g. an
+	// EVSE ID, CDR ID, or token UID depending on the procedure.
+	ID string
+	// ResultCode is the per-item resultCode reported by the server.
+	ResultCode string
+	// ResultDescription is the per-item resultDescription reported by the
+	// server, if any.
+	ResultDescription string
+	// Raw is the raw XML element the server returned for this item, for
+	// callers that need access to fields this type does not expose.
+	Raw []byte
+}
+
+// Details returns the raw, untyped error details attached to e, analogous to
+// Protobuf/Connect ErrorDetails. Most callers should use a typed accessor
+// such as FailedRecords instead.
+func (e ochpErr) Details() []any {
+	return e.details
+}
+
+// FailedRecords returns the FailedRecord details attached to e, if any. It is
+// populated by batch procedures (SetChargePointList, SetRoamingAuthorisationList,
+// SetCDRs, etc.) from the per-item result fields of the OCHP response when
+// they return resultCode "partly".
+func (e ochpErr) FailedRecords() []FailedRecord {
+	var records []FailedRecord
+	for _, d := range e.details {
+		if r, ok := d.(FailedRecord); ok {
+			records = append(records, r)
+		}
+	}
+	return records
+}
+
+// withDetails returns a copy of e with details appended. It is used by
+// NewErrPartly to attach FailedRecords (or other detail types) to an
+// ErrPartly before returning it.
+func (e ochpErr) withDetails(details ...any) ochpErr {
+	e.details = append(e.details, details...)
+	return e
+}
+
+// NewErrPartly builds the ErrPartly that a batch procedure (SetChargePointList,
+// SetRoamingAuthorisationList, SetCDRs, etc.) returns when the OCHP Result
+// object had resultCode "partly", attaching the given FailedRecords as
+// details. Generated procedure wrappers construct records from the per-item
+// resultCode/resultDescription/raw XML element of the response and call this
+// instead of building an ErrPartly by hand.
+func NewErrPartly(httpResponse *http.Response, resultDescription string, records ...FailedRecord) ErrPartly {
+	details := make([]any, len(records))
+	for i, r := range records {
+		details[i] = r
+	}
+
+	base := ochpErr{
+		ErrDecode:         ErrDecode{httpResponse: httpResponse},
+		resultCode:        string(CodePartly),
+		resultDescription: resultDescription,
+	}
+	return ErrPartly{base.withDetails(details...)}
+}
@@ -0,0 +1,43 @@
+package ochp
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestNewErrPartlyPopulatesFailedRecords(t *testing.T) {
+	records := []FailedRecord{
+		{ID: "EVSE-1", ResultCode: "not-found", ResultDescription: "unknown EVSE"},
+		{ID: "EVSE-2", ResultCode: "format", ResultDescription: "bad geo coordinates"},
+	}
+
+	err := NewErrPartly(&http.Response{StatusCode: 200}, "2 of 10 records failed", records...)
+
+	if got := err.Code(); got != CodePartly {
+		t.Errorf("Code() = %q, want %q", got, CodePartly)
+	}
+	if got := err.ResultDescription(); got != "2 of 10 records failed" {
+		t.Errorf("ResultDescription() = %q, want %q", got, "2 of 10 records failed")
+	}
+
+	got := err.FailedRecords()
+	if len(got) != len(records) {
+		t.Fatalf("FailedRecords() returned %d records, want %d", len(got), len(records))
+	}
+	for i, r := range records {
+		if got[i].ID != r.ID || got[i].ResultCode != r.ResultCode || got[i].ResultDescription != r.ResultDescription {
+			t.Errorf("FailedRecords()[%d] = %+v, want %+v", i, got[i], r)
+		}
+	}
+
+	if len(err.Details()) != len(records) {
+		t.Errorf("Details() returned %d entries, want %d", len(err.Details()), len(records))
+	}
+}
+
+func TestFailedRecordsEmptyWhenNoDetailsAttached(t *testing.T) {
+	err := ErrPartly{ochpErr{resultCode: string(CodePartly)}}
+	if got := err.FailedRecords(); got != nil {
+		t.Errorf("FailedRecords() = %v, want nil", got)
+	}
+}
@@ -1,6 +1,7 @@
 package ochp
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
 )
@@ -28,6 +29,25 @@ type OchpErr interface {
 	// HTTP request. As copying this introduces computational and memory overhead,
 	// it is only returned if the client has been configured to do so.
 	HttpResponseBody() []byte
+	// Code classifies the error, analogous to a gRPC or Twirp status code. Use
+	// errors.Is(err, ochp.ErrCodeXxx) rather than comparing Code() directly so
+	// that wrapped errors are still matched correctly.
+	Code() Code
+	// RequestID may return a correlation ID extracted from the response, e.g.
+	// a WS-Addressing MessageID/RelatesTo header or an operator-specific
+	// correlation header. It returns "" if none was found. Prefer
+	// ochp.RequestIDOf(err) over a type assertion, since it also walks the
+	// wrap chain.
+	RequestID() string
+	// Operation may return the name of the procedure that produced this
+	// error, e.g. "SetChargePointList", as set by the Client that dispatched
+	// the call. It returns "" if the error was constructed without that
+	// context. Prefer ochp.OperationOf(err) over a type assertion, since it
+	// also walks the wrap chain.
+	Operation() string
+	// MarshalJSON produces a stable schema (code, description, httpStatus,
+	// operation, requestId, wrapped) suitable for structured logging pipelines.
+	json.Marshaler
 }
 
 /* Err is returned when the underlying HTTP request was not able to succeed for
@@ -67,6 +87,29 @@ func (e Err) HttpResponseBody() []byte {
 	return nil
 }
 
+// Code always returns CodeUnknown, since Err carries no OCHP resultCode.
+func (e Err) Code() Code {
+	return CodeUnknown
+}
+
+// Is allows errors.Is(err, ochp.ErrCodeXxx) to match this error by Code.
+func (e Err) Is(target error) bool {
+	return is(e.Code(), target)
+}
+
+// RequestID always returns "", since Err carries no response to extract a
+// correlation ID from.
+func (e Err) RequestID() string {
+	return ""
+}
+
+// Operation always returns "", since Err is constructed before the Client
+// knows which operation it belongs to. A Client tags returned errors with
+// their operation; see withOperation.
+func (e Err) Operation() string {
+	return ""
+}
+
 /* ErrDecode is returned when the underlying HTTP request suceeded, but it
  * wasn't able to be deserialized properly. It thus returns zero values for all
  * OCHP related methods. It generally contains a wrapped error returned by
@@ -117,6 +160,14 @@ type ochpErr struct {
 	ErrDecode
 	resultCode        string
 	resultDescription string
+	// details holds structured data describing which part of a request the
+	// procedure wasn't able to process, e.g. FailedRecord for batch procedures
+	// that return resultCode "partly". See Details and FailedRecords.
+	details []any
+	// requestID holds a correlation ID extracted from the response, e.g. a
+	// WS-Addressing MessageID/RelatesTo header or an operator-specific
+	// correlation header. See RequestID.
+	requestID string
 }
 
 func (e ochpErr) Error() string {
@@ -139,9 +190,35 @@ func (e ochpErr) ResultDescription() string {
 	return e.resultDescription
 }
 
+// Code classifies e based on its resultCode, falling back to CodeUnknown if
+// the resultCode is empty or not one of the known values.
+func (e ochpErr) Code() Code {
+	if code, ok := codeForResultCode[e.resultCode]; ok {
+		return code
+	}
+	return CodeUnknown
+}
+
+// Is allows errors.Is(err, ochp.ErrCodeXxx) to match this error by Code. It
+// shadows Err.Is so that promotion through ErrPartly, ErrNotFound, etc. uses
+// ochpErr's resultCode-derived Code rather than Err's fixed CodeUnknown.
+func (e ochpErr) Is(target error) bool {
+	return is(e.Code(), target)
+}
+
+// RequestID returns the correlation ID stored in e when it was constructed,
+// e.g. a WS-Addressing MessageID or RelatesTo header or an operator-specific
+// correlation header, so operators can grep both sides of an incident from a
+// single ID. It returns "" if none was stored. This package does not itself
+// read SOAP headers; requestID is only set by code that builds e.
+func (e ochpErr) RequestID() string {
+	return e.requestID
+}
+
 // ErrPartly is returned when the OCHP Result object had resultCode "partly".
 // For certain procedures, this error may be returned together with data
-// describing which data the procedure wasn't able to process.
+// describing which data the procedure wasn't able to process. That data is
+// available through Details and the typed FailedRecords accessor.
 type ErrPartly struct{ ochpErr }
 
 // ErrNotFound is returned when the OCHP Result object had resultCode "not-found"
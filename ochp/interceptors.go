@@ -0,0 +1,100 @@
+package ochp
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+)
+
+// Sanitizer redacts or trims a request or response body before it is logged,
+// e.g. to strip auth tokens, PII, or truncate large payloads. operation is
+// the procedure name, so a Sanitizer can vary its behavior per procedure.
+type Sanitizer func(operation string, body any) any
+
+// LoggingInterceptor returns an Interceptor that logs each call's operation,
+// outcome, and request body to logger, plus the error's Code on failure or
+// the response body on success. Bodies are passed through sanitize before
+// logging; pass nil to log them unmodified.
+func LoggingInterceptor(logger *log.Logger, sanitize Sanitizer) Interceptor {
+	if sanitize == nil {
+		sanitize = func(_ string, body any) any { return body }
+	}
+
+	return func(next Invoker) Invoker {
+		return func(ctx context.Context, operation string, req any) (any, OchpErr) {
+			resp, err := next(ctx, operation, req)
+			if err != nil {
+				logger.Printf("ochp: %s failed with code %q: %s request=%+v", operation, err.Code(), err.Error(), sanitize(operation, req))
+			} else {
+				logger.Printf("ochp: %s succeeded request=%+v response=%+v", operation, sanitize(operation, req), sanitize(operation, resp))
+			}
+			return resp, err
+		}
+	}
+}
+
+// MetricsRecorder receives one observation per completed call. Implementations
+// typically forward this to Prometheus or OpenTelemetry, keyed by operation
+// and code.
+type MetricsRecorder interface {
+	Observe(operation string, code Code, duration time.Duration)
+}
+
+// MetricsInterceptor returns an Interceptor that reports call latency and
+// outcome to recorder, keyed by operation and the error's Code (CodeOK when
+// the call succeeded).
+func MetricsInterceptor(recorder MetricsRecorder) Interceptor {
+	return func(next Invoker) Invoker {
+		return func(ctx context.Context, operation string, req any) (any, OchpErr) {
+			start := time.Now()
+			resp, err := next(ctx, operation, req)
+			code := CodeOK
+			if err != nil {
+				code = err.Code()
+			}
+			recorder.Observe(operation, code, time.Since(start))
+			return resp, err
+		}
+	}
+}
+
+// CircuitBreakerInterceptor returns an Interceptor that opens the circuit
+// after consecutiveFailures retryable failures in a row, failing fast with
+// the last observed error for cooldown before allowing calls through again.
+func CircuitBreakerInterceptor(consecutiveFailures int, cooldown time.Duration) Interceptor {
+	var (
+		mu          sync.Mutex
+		failures    int
+		openedUntil time.Time
+		lastErr     OchpErr
+	)
+
+	return func(next Invoker) Invoker {
+		return func(ctx context.Context, operation string, req any) (any, OchpErr) {
+			mu.Lock()
+			if !openedUntil.IsZero() && time.Now().Before(openedUntil) {
+				err := lastErr
+				mu.Unlock()
+				return nil, err
+			}
+			mu.Unlock()
+
+			resp, err := next(ctx, operation, req)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil && IsRetryable(err) {
+				failures++
+				lastErr = err
+				if failures >= consecutiveFailures {
+					openedUntil = time.Now().Add(cooldown)
+				}
+			} else {
+				failures = 0
+				openedUntil = time.Time{}
+			}
+			return resp, err
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package ochp
+
+import (
+	"context"
+	"log"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLoggingInterceptorSanitizesRequestAndResponseBodies(t *testing.T) {
+	inv := func(_ context.Context, operation string, req any) (any, OchpErr) {
+		return "token=secret-response", nil
+	}
+
+	var buf strings.Builder
+	logger := log.New(&buf, "", 0)
+	sanitize := func(_ string, body any) any {
+		return strings.ReplaceAll(body.(string), "secret", "REDACTED")
+	}
+
+	_, _ = LoggingInterceptor(logger, sanitize)(inv)(context.Background(), "SetChargePointList", "token=secret-request")
+
+	out := buf.String()
+	if strings.Contains(out, "secret-request") || strings.Contains(out, "secret-response") {
+		t.Errorf("log output contains unsanitized secret: %q", out)
+	}
+	if !strings.Contains(out, "REDACTED-request") || !strings.Contains(out, "REDACTED-response") {
+		t.Errorf("log output missing sanitized bodies: %q", out)
+	}
+}
+
+func TestLoggingInterceptorNilSanitizerLogsBodiesUnmodified(t *testing.T) {
+	inv := func(_ context.Context, operation string, req any) (any, OchpErr) {
+		return "response-body", nil
+	}
+
+	var buf strings.Builder
+	logger := log.New(&buf, "", 0)
+
+	_, _ = LoggingInterceptor(logger, nil)(inv)(context.Background(), "SetChargePointList", "request-body")
+
+	out := buf.String()
+	if !strings.Contains(out, "request-body") || !strings.Contains(out, "response-body") {
+		t.Errorf("log output missing request/response bodies: %q", out)
+	}
+}
+
+func TestCircuitBreakerInterceptorOpensAfterConsecutiveFailures(t *testing.T) {
+	calls := 0
+	inv := func(_ context.Context, operation string, req any) (any, OchpErr) {
+		calls++
+		return nil, ErrServer{ochpErr{resultCode: string(CodeServer)}}
+	}
+
+	breaker := CircuitBreakerInterceptor(2, time.Minute)(inv)
+
+	for i := 0; i < 2; i++ {
+		if _, err := breaker(context.Background(), "SetChargePointList", nil); err == nil {
+			t.Fatalf("attempt %d: expected an error", i)
+		}
+	}
+	if calls != 2 {
+		t.Fatalf("calls = %d, want 2 before the circuit opens", calls)
+	}
+
+	if _, err := breaker(context.Background(), "SetChargePointList", nil); err == nil {
+		t.Fatal("expected the open circuit to fail fast with the last error")
+	}
+	if calls != 2 {
+		t.Fatalf("calls = %d, want 2: an open circuit must not call next", calls)
+	}
+}
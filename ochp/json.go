@@ -0,0 +1,127 @@
+package ochp
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// errJSON is the stable schema produced by MarshalJSON on every OchpErr,
+// following the Azure ResponseError / Aliyun ServiceError / Docker registry
+// error conventions so it can be fed straight into a structured logging
+// pipeline.
+type errJSON struct {
+	Code        Code   `json:"code"`
+	Description string `json:"description"`
+	HttpStatus  int    `json:"httpStatus,omitempty"`
+	Operation   string `json:"operation,omitempty"`
+	RequestID   string `json:"requestId,omitempty"`
+	Wrapped     string `json:"wrapped,omitempty"`
+}
+
+func wrappedMessage(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
+
+// MarshalJSON implements json.Marshaler.
+func (e Err) MarshalJSON() ([]byte, error) {
+	return json.Marshal(errJSON{
+		Code:        e.Code(),
+		Description: e.Error(),
+		Wrapped:     wrappedMessage(e.Unwrap()),
+	})
+}
+
+// MarshalJSON implements json.Marshaler.
+func (e ErrDecode) MarshalJSON() ([]byte, error) {
+	j := errJSON{
+		Code:        e.Code(),
+		Description: e.Error(),
+		Wrapped:     wrappedMessage(e.Unwrap()),
+	}
+	if resp := e.HttpResponse(); resp != nil {
+		j.HttpStatus = resp.StatusCode
+	}
+	return json.Marshal(j)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (e ochpErr) MarshalJSON() ([]byte, error) {
+	description := e.resultDescription
+	if description == "" {
+		description = e.Error()
+	}
+
+	j := errJSON{
+		Code:        e.Code(),
+		Description: description,
+		RequestID:   e.requestID,
+		Wrapped:     wrappedMessage(e.Unwrap()),
+	}
+	if resp := e.HttpResponse(); resp != nil {
+		j.HttpStatus = resp.StatusCode
+	}
+	return json.Marshal(j)
+}
+
+// withOperation wraps err so that its Operation() and MarshalJSON() report
+// operation, without needing to know err's concrete type. errors.Is and
+// errors.As still see through to err via Unwrap.
+type withOperation struct {
+	OchpErr
+	operation string
+}
+
+// Operation returns the name of the procedure that produced this error, e.g.
+// "SetChargePointList".
+func (e withOperation) Operation() string {
+	return e.operation
+}
+
+func (e withOperation) Unwrap() error {
+	return e.OchpErr
+}
+
+// MarshalJSON implements json.Marshaler, adding operation to the wrapped
+// error's JSON representation.
+func (e withOperation) MarshalJSON() ([]byte, error) {
+	data, err := e.OchpErr.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	var j errJSON
+	if err := json.Unmarshal(data, &j); err != nil {
+		return nil, err
+	}
+	j.Operation = e.operation
+	return json.Marshal(j)
+}
+
+// RequestIDOf walks the wrap chain of err looking for an OchpErr and returns
+// its RequestID. It returns "" if err is nil or does not wrap an OchpErr.
+func RequestIDOf(err error) string {
+	if err == nil {
+		return ""
+	}
+	var oe OchpErr
+	if errors.As(err, &oe) {
+		return oe.RequestID()
+	}
+	return ""
+}
+
+// OperationOf walks the wrap chain of err looking for an OchpErr and returns
+// its Operation. It returns "" if err is nil or does not wrap an OchpErr.
+func OperationOf(err error) string {
+	if err == nil {
+		return ""
+	}
+	var oe OchpErr
+	if errors.As(err, &oe) {
+		return oe.Operation()
+	}
+	return ""
+}
@@ -0,0 +1,67 @@
+package ochp
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestWithOperationUnwrapAndAsStillMatchConcreteType(t *testing.T) {
+	inner := ErrNotFound{ochpErr{resultCode: string(CodeNotFound), resultDescription: "no such EVSE"}}
+	var wrapped OchpErr = withOperation{OchpErr: inner, operation: "GetChargePointList"}
+
+	var notFound ErrNotFound
+	if !errors.As(wrapped, &notFound) {
+		t.Fatal("errors.As should see through withOperation to the wrapped ErrNotFound")
+	}
+	if notFound.ResultDescription() != "no such EVSE" {
+		t.Errorf("ResultDescription() = %q, want %q", notFound.ResultDescription(), "no such EVSE")
+	}
+
+	if !errors.Is(wrapped, ErrCodeNotFound) {
+		t.Error("errors.Is should see through withOperation to match the sentinel Code")
+	}
+}
+
+func TestWithOperationMarshalJSONIncludesOperation(t *testing.T) {
+	inner := ErrServer{ochpErr{resultCode: string(CodeServer), resultDescription: "internal error"}}
+	wrapped := withOperation{OchpErr: inner, operation: "SetChargePointList"}
+
+	data, err := wrapped.MarshalJSON()
+	if err != nil {
+		t.Fatalf("MarshalJSON: %v", err)
+	}
+
+	var got errJSON
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if got.Operation != "SetChargePointList" {
+		t.Errorf("Operation = %q, want %q", got.Operation, "SetChargePointList")
+	}
+	if got.Code != CodeServer {
+		t.Errorf("Code = %q, want %q", got.Code, CodeServer)
+	}
+	if got.Description != "internal error" {
+		t.Errorf("Description = %q, want %q", got.Description, "internal error")
+	}
+}
+
+func TestRequestIDOfAndOperationOf(t *testing.T) {
+	inner := ErrPartly{ochpErr{resultCode: string(CodePartly), requestID: "req-123"}}
+	wrapped := withOperation{OchpErr: inner, operation: "SetCDRs"}
+
+	if got := RequestIDOf(wrapped); got != "req-123" {
+		t.Errorf("RequestIDOf = %q, want %q", got, "req-123")
+	}
+	if got := OperationOf(wrapped); got != "SetCDRs" {
+		t.Errorf("OperationOf = %q, want %q", got, "SetCDRs")
+	}
+
+	if got := RequestIDOf(nil); got != "" {
+		t.Errorf("RequestIDOf(nil) = %q, want \"\"", got)
+	}
+	if got := OperationOf(errors.New("not an OchpErr")); got != "" {
+		t.Errorf("OperationOf(plain error) = %q, want \"\"", got)
+	}
+}
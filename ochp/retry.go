@@ -0,0 +1,153 @@
+package ochp
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"syscall"
+	"time"
+)
+
+// IsRetryable reports whether err is worth retrying: either the OCHP result
+// code indicates a transient server-side failure (CodeServer, CodeRoaming),
+// or the call failed below the OCHP layer in a way IsTransient recognizes.
+// It walks the full errors.Unwrap chain, so it correctly classifies errors
+// wrapped by ErrDecode, ErrEmptyResponse, or further wrapping by callers.
+func IsRetryable(err error) bool {
+	if err == nil {
+		return false
+	}
+	switch CodeOf(err) {
+	case CodeServer, CodeRoaming:
+		return true
+	}
+	return IsTransient(err)
+}
+
+// IsTransient reports whether err represents a failure that is likely to
+// succeed on a subsequent attempt: timeouts, connection resets, refused
+// connections, TLS handshake failures, an unexpectedly empty response body,
+// or an HTTP 5xx status (e.g. a reverse-proxy error page that doesn't decode
+// as OCHP XML, which otherwise surfaces as an ErrDecode with no resultCode).
+// Unlike IsRetryable, it does not consider the OCHP result code.
+func IsTransient(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var emptyResp ErrEmptyResponse
+	if errors.As(err, &emptyResp) {
+		return true
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+
+	if errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+
+	var oe OchpErr
+	if errors.As(err, &oe) {
+		if resp := oe.HttpResponse(); resp != nil && resp.StatusCode >= 500 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// RetryPolicy configures automatic retries of OCHP calls. The zero value
+// makes a single attempt and never retries; start from DefaultRetryPolicy and
+// adjust as needed.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first. A
+	// value <= 1 disables retries.
+	MaxAttempts int
+	// BaseDelay is the delay before the first retry. Each subsequent retry
+	// doubles the previous delay.
+	BaseDelay time.Duration
+	// MaxDelay caps the computed delay between attempts. Zero means no cap.
+	MaxDelay time.Duration
+	// Jitter is the fraction (0-1) of random variance applied to each delay,
+	// to avoid synchronized retries across clients.
+	Jitter float64
+	// ExcludedOperations lists operation names that must never be retried
+	// automatically, e.g. non-idempotent procedures like SetCDRs. Matching is
+	// exact and case-sensitive.
+	ExcludedOperations []string
+}
+
+// DefaultRetryPolicy returns a RetryPolicy with conservative defaults: 3
+// attempts, 200ms base delay doubling up to 5s, 20% jitter, and SetCDRs
+// excluded since repeating it can duplicate billing-relevant CDRs.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:        3,
+		BaseDelay:          200 * time.Millisecond,
+		MaxDelay:           5 * time.Second,
+		Jitter:             0.2,
+		ExcludedOperations: []string{"SetCDRs"},
+	}
+}
+
+func (p RetryPolicy) allows(operation string) bool {
+	for _, excluded := range p.ExcludedOperations {
+		if excluded == operation {
+			return false
+		}
+	}
+	return true
+}
+
+func (p RetryPolicy) delay(attempt int) time.Duration {
+	d := p.BaseDelay << attempt
+	if p.Jitter > 0 {
+		d += time.Duration((rand.Float64()*2 - 1) * p.Jitter * float64(d))
+	}
+	// Cap after jitter, not before: jitter applied to an already-capped delay
+	// could push it back above MaxDelay.
+	if p.MaxDelay > 0 && d > p.MaxDelay {
+		d = p.MaxDelay
+	}
+	return d
+}
+
+// Interceptor builds an Interceptor that retries calls according to p: up to
+// MaxAttempts attempts, with exponential backoff between them, skipping
+// operations in ExcludedOperations and errors IsRetryable reports as
+// non-retryable.
+func (p RetryPolicy) Interceptor() Interceptor {
+	return func(next Invoker) Invoker {
+		return func(ctx context.Context, operation string, req any) (any, OchpErr) {
+			maxAttempts := p.MaxAttempts
+			if maxAttempts < 1 {
+				maxAttempts = 1
+			}
+			if !p.allows(operation) {
+				maxAttempts = 1
+			}
+
+			var resp any
+			var err OchpErr
+			for attempt := 0; attempt < maxAttempts; attempt++ {
+				resp, err = next(ctx, operation, req)
+				if err == nil || !IsRetryable(err) {
+					return resp, err
+				}
+				if attempt < maxAttempts-1 {
+					select {
+					case <-time.After(p.delay(attempt)):
+					case <-ctx.Done():
+						return resp, err
+					}
+				}
+			}
+			return resp, err
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package ochp
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestIsTransientHttpStatus(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantResult bool
+	}{
+		{
+			name:       "5xx reverse-proxy error page decodes as ErrDecode",
+			err:        ErrDecode{httpResponse: &http.Response{StatusCode: 503}},
+			wantResult: true,
+		},
+		{
+			name:       "2xx ErrDecode is not transient",
+			err:        ErrDecode{httpResponse: &http.Response{StatusCode: 200}},
+			wantResult: false,
+		},
+		{
+			name:       "4xx ErrHttp is not transient",
+			err:        ErrHttp{ochpErr{ErrDecode: ErrDecode{httpResponse: &http.Response{StatusCode: 404}}}},
+			wantResult: false,
+		},
+		{
+			name:       "nil error is not transient",
+			err:        nil,
+			wantResult: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsTransient(tt.err); got != tt.wantResult {
+				t.Errorf("IsTransient(%v) = %v, want %v", tt.err, got, tt.wantResult)
+			}
+		})
+	}
+}
+
+func TestIsRetryableConsidersHttpStatus(t *testing.T) {
+	err := ErrDecode{httpResponse: &http.Response{StatusCode: 503}}
+	if !IsRetryable(err) {
+		t.Errorf("IsRetryable(%v) = false, want true for a 503 that failed to decode as OCHP XML", err)
+	}
+}
+
+func TestRetryPolicyDelayRespectsMaxDelayAfterJitter(t *testing.T) {
+	p := RetryPolicy{
+		BaseDelay: 10 * time.Second,
+		MaxDelay:  1 * time.Second,
+		Jitter:    0.9,
+	}
+
+	for attempt := 0; attempt < 5; attempt++ {
+		if d := p.delay(attempt); d > p.MaxDelay {
+			t.Errorf("delay(%d) = %v, want <= MaxDelay %v even after jitter", attempt, d, p.MaxDelay)
+		}
+	}
+}
+
+func TestRetryPolicyInterceptorClampsNonPositiveMaxAttempts(t *testing.T) {
+	p := RetryPolicy{MaxAttempts: 0}
+	calls := 0
+	inv := func(_ context.Context, operation string, req any) (any, OchpErr) {
+		calls++
+		return "ok", nil
+	}
+
+	resp, err := p.Interceptor()(inv)(context.Background(), "GetChargePointList", nil)
+	if calls != 1 {
+		t.Fatalf("calls = %d, want 1: MaxAttempts <= 0 must still invoke next at least once", calls)
+	}
+	if err != nil {
+		t.Fatalf("err = %v, want nil", err)
+	}
+	if resp != "ok" {
+		t.Fatalf("resp = %v, want %q", resp, "ok")
+	}
+}
+
+func TestRetryPolicyInterceptorSkipsExcludedOperations(t *testing.T) {
+	p := RetryPolicy{MaxAttempts: 3, ExcludedOperations: []string{"SetCDRs"}}
+	calls := 0
+	inv := func(_ context.Context, operation string, req any) (any, OchpErr) {
+		calls++
+		return nil, ErrServer{ochpErr{resultCode: string(CodeServer)}}
+	}
+
+	_, err := p.Interceptor()(inv)(context.Background(), "SetCDRs", nil)
+	if err == nil {
+		t.Fatal("expected an error to be returned")
+	}
+	if calls != 1 {
+		t.Errorf("calls = %d, want 1 (excluded operations must not be retried)", calls)
+	}
+}